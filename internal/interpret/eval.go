@@ -0,0 +1,141 @@
+package interpret
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Evaluator executes a parsed PostScript program against an operand
+// stack and a dictionary stack. There is no explicit execution stack;
+// nested procedures run via ordinary Go calls through Evaluator.run.
+type Evaluator struct {
+	operands []Object
+	dicts    []*DictObject
+	out      io.Writer
+}
+
+// EvalOption configures an Evaluator. See NewEvaluator.
+type EvalOption func(*Evaluator)
+
+// WithOutput sets where the "=" and "stack" operators write, in place of
+// the default os.Stdout.
+func WithOutput(w io.Writer) EvalOption {
+	return func(e *Evaluator) {
+		e.out = w
+	}
+}
+
+// NewEvaluator returns an Evaluator with systemdict (the built-in
+// operators and booleans) and a fresh userdict pushed on the dictionary
+// stack.
+func NewEvaluator(opts ...EvalOption) *Evaluator {
+	system := NewDict()
+	registerOperators(system)
+
+	e := &Evaluator{
+		dicts: []*DictObject{system, NewDict()},
+		out:   os.Stdout,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Interpret parses and executes a PostScript program read from r.
+func Interpret(r io.Reader) error {
+	return NewEvaluator().Run(r)
+}
+
+// Run parses and executes the program read from r.
+func (e *Evaluator) Run(r io.Reader) error {
+	program, err := NewParser(NewScanner(r)).Parse()
+	if err != nil {
+		return err
+	}
+	return e.run(program)
+}
+
+// run executes a sequence of Objects in order, as either a top-level
+// program or a procedure's body.
+func (e *Evaluator) run(body []Object) error {
+	for _, obj := range body {
+		if err := e.execute(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execute runs a single Object the way the interpreter would encounter
+// it directly in a program: an executable name is looked up and
+// invoked; an immediately-evaluated name ("//name") is looked up and
+// pushed without being invoked; everything else (including a literal
+// procedure) is pushed as-is.
+func (e *Evaluator) execute(obj Object) error {
+	switch v := obj.(type) {
+	case NameObject:
+		value, err := e.lookup(v.Value)
+		if err != nil {
+			return err
+		}
+		return e.invoke(value)
+	case ImmediateNameObject:
+		value, err := e.lookup(v.Value)
+		if err != nil {
+			return err
+		}
+		e.push(value)
+		return nil
+	default:
+		e.push(obj)
+		return nil
+	}
+}
+
+// invoke runs a value that something (a name lookup, or an operator
+// like exec/if/ifelse/for) has asked to be executed: an operator is
+// called and a procedure's body is run, anything else is pushed back as
+// a literal.
+func (e *Evaluator) invoke(obj Object) error {
+	switch v := obj.(type) {
+	case OperatorObject:
+		return v.Fn(e)
+	case *ProcedureObject:
+		return e.run(v.Body)
+	default:
+		e.push(obj)
+		return nil
+	}
+}
+
+func (e *Evaluator) lookup(name string) (Object, error) {
+	for i := len(e.dicts) - 1; i >= 0; i-- {
+		if value, ok := e.dicts[i].Entries[name]; ok {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("undefined: %s", name)
+}
+
+func (e *Evaluator) currentDict() *DictObject {
+	return e.dicts[len(e.dicts)-1]
+}
+
+func (e *Evaluator) push(obj Object) {
+	e.operands = append(e.operands, obj)
+}
+
+func (e *Evaluator) pop() (Object, error) {
+	n := len(e.operands)
+	if n == 0 {
+		return nil, fmt.Errorf("stackunderflow")
+	}
+
+	obj := e.operands[n-1]
+	e.operands = e.operands[:n-1]
+	return obj, nil
+}
@@ -0,0 +1,116 @@
+package interpret_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ian-shakespeare/libps/internal/interpret"
+	"github.com/stretchr/testify/assert"
+)
+
+func runInterpret(t *testing.T, program string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	err := interpret.NewEvaluator(interpret.WithOutput(&out)).Run(strings.NewReader(program))
+	assert.NoError(t, err)
+	return out.String()
+}
+
+func TestEval(t *testing.T) {
+	t.Parallel()
+
+	t.Run("arithmetic", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "7\n", runInterpret(t, "3 4 add ="))
+		assert.Equal(t, "6\n", runInterpret(t, "10 4 sub ="))
+		assert.Equal(t, "12\n", runInterpret(t, "3 4 mul ="))
+		assert.Equal(t, "2.5\n", runInterpret(t, "5 2 div ="))
+	})
+
+	t.Run("mixedArithmeticPromotesToReal", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "3.5\n", runInterpret(t, "1 2.5 add ="))
+	})
+
+	t.Run("dupPopExch", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "2\n", runInterpret(t, "1 2 exch pop dup ="))
+	})
+
+	t.Run("defAndLookup", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "7\n", runInterpret(t, "/x 3 def /y 4 def x y add ="))
+	})
+
+	t.Run("immediateNamePushesWithoutInvoking", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "--nostringval--\n", runInterpret(t, "/foo { 1 } def //foo ="))
+	})
+
+	t.Run("ifTrue", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "1\n", runInterpret(t, "true { 1 } if ="))
+	})
+
+	t.Run("ifFalseSkipsBody", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+		err := interpret.NewEvaluator(interpret.WithOutput(&out)).Run(strings.NewReader("false { 1 = } if"))
+		assert.NoError(t, err)
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("ifElse", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "2\n", runInterpret(t, "false { 1 } { 2 } ifelse ="))
+	})
+
+	t.Run("for", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "1\n2\n3\n", runInterpret(t, "1 1 3 { = } for"))
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "7\n", runInterpret(t, "{ 3 4 add } exec ="))
+	})
+
+	t.Run("stack", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "2\n1\n", runInterpret(t, "1 2 stack"))
+	})
+
+	t.Run("stackUnderflow", func(t *testing.T) {
+		t.Parallel()
+
+		err := interpret.NewEvaluator().Run(strings.NewReader("add"))
+		assert.Error(t, err)
+	})
+
+	t.Run("undefinedName", func(t *testing.T) {
+		t.Parallel()
+
+		err := interpret.NewEvaluator().Run(strings.NewReader("bogusname"))
+		assert.Error(t, err)
+	})
+
+	t.Run("divisionByZero", func(t *testing.T) {
+		t.Parallel()
+
+		err := interpret.NewEvaluator().Run(strings.NewReader("1 0 div"))
+		assert.Error(t, err)
+	})
+}
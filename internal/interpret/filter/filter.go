@@ -0,0 +1,161 @@
+// Package filter implements the PostScript ASCIIHexDecode/ASCIIHexEncode
+// and ASCII85Decode/ASCII85Encode filters as reusable io.Reader and
+// io.Writer wrappers, so the scanner (and anyone else) can decode or
+// produce these encodings without duplicating the logic inline.
+package filter
+
+import (
+	"bufio"
+	"encoding/ascii85"
+	"fmt"
+	"io"
+)
+
+// NewASCII85Decoder returns a reader that decodes the ASCII85 (Adobe
+// "base-85") encoding read from r, including the "z" shortcut for four
+// zero bytes. It is a thin wrapper around encoding/ascii85, which
+// already implements the variant PostScript and PDF use.
+func NewASCII85Decoder(r io.Reader) io.Reader {
+	return ascii85.NewDecoder(r)
+}
+
+// NewASCII85Encoder returns a writer that ASCII85-encodes bytes written
+// to it and writes the result to w. The caller must call Close to flush
+// any partial group buffered at the end of the input.
+func NewASCII85Encoder(w io.Writer) io.WriteCloser {
+	return ascii85.NewEncoder(w)
+}
+
+// hexDecoder decodes an ASCIIHex stream: pairs of hex digits, with
+// whitespace ignored between them, and an odd trailing digit padded
+// with a trailing zero.
+type hexDecoder struct {
+	r    *bufio.Reader
+	done bool
+}
+
+// NewASCIIHexDecoder returns a reader that decodes the ASCIIHex
+// encoding read from r. Whitespace between digits is ignored; a dangling
+// final digit is padded with a trailing zero, matching the scanner's own
+// hex-string handling (e.g. "901fa" decodes as if it were "901fa0").
+func NewASCIIHexDecoder(r io.Reader) io.Reader {
+	return &hexDecoder{r: bufio.NewReader(r)}
+}
+
+func (d *hexDecoder) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		b, ok, err := d.nextByte()
+		if err != nil {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, err
+		}
+		if !ok {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, io.EOF
+		}
+		p[n] = b
+		n++
+	}
+	return n, nil
+}
+
+// nextByte decodes the next pair of hex digits into a byte. ok is false
+// once the input is exhausted.
+func (d *hexDecoder) nextByte() (byte, bool, error) {
+	if d.done {
+		return 0, false, nil
+	}
+
+	var digits []byte
+	for len(digits) < 2 {
+		r, err := d.r.ReadByte()
+		if err != nil {
+			if err != io.EOF {
+				return 0, false, err
+			}
+			d.done = true
+			break
+		}
+		if isHexSpace(r) {
+			continue
+		}
+		digits = append(digits, r)
+	}
+
+	if len(digits) == 0 {
+		return 0, false, nil
+	}
+	if len(digits) == 1 {
+		digits = append(digits, '0')
+	}
+
+	hi, err := hexVal(digits[0])
+	if err != nil {
+		return 0, false, err
+	}
+	lo, err := hexVal(digits[1])
+	if err != nil {
+		return 0, false, err
+	}
+
+	return hi<<4 | lo, true, nil
+}
+
+func isHexSpace(r byte) bool {
+	switch r {
+	case ' ', '\t', '\n', '\r', '\f', 0:
+		return true
+	default:
+		return false
+	}
+}
+
+func hexVal(r byte) (byte, error) {
+	switch {
+	case r >= '0' && r <= '9':
+		return r - '0', nil
+	case r >= 'a' && r <= 'f':
+		return r - 'a' + 10, nil
+	case r >= 'A' && r <= 'F':
+		return r - 'A' + 10, nil
+	default:
+		return 0, fmt.Errorf("filter: invalid hex digit %q", r)
+	}
+}
+
+// hexEncoder ASCIIHex-encodes bytes written to it.
+type hexEncoder struct {
+	w io.Writer
+}
+
+// NewASCIIHexEncoder returns a writer that ASCIIHex-encodes bytes
+// written to it and writes the result to w. Unlike NewASCII85Encoder,
+// there is no trailing state to flush, but Close is provided so the two
+// encoders share an interface.
+func NewASCIIHexEncoder(w io.Writer) io.WriteCloser {
+	return &hexEncoder{w: w}
+}
+
+func (e *hexEncoder) Write(p []byte) (int, error) {
+	const hexDigits = "0123456789abcdef"
+
+	buf := make([]byte, 2*len(p))
+	for i, b := range p {
+		buf[2*i] = hexDigits[b>>4]
+		buf[2*i+1] = hexDigits[b&0xf]
+	}
+
+	if _, err := e.w.Write(buf); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (e *hexEncoder) Close() error {
+	return nil
+}
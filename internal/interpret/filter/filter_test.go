@@ -0,0 +1,89 @@
+package filter_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ian-shakespeare/libps/internal/interpret/filter"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestASCIIHexDecoder(t *testing.T) {
+	t.Parallel()
+
+	t.Run("decodesPairs", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := io.ReadAll(filter.NewASCIIHexDecoder(strings.NewReader("901fa0")))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x90, 0x1f, 0xa0}, out)
+	})
+
+	t.Run("padsDanglingDigit", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := io.ReadAll(filter.NewASCIIHexDecoder(strings.NewReader("901fa")))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x90, 0x1f, 0xa0}, out)
+	})
+
+	t.Run("ignoresWhitespace", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := io.ReadAll(filter.NewASCIIHexDecoder(strings.NewReader("90 1f\na0")))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x90, 0x1f, 0xa0}, out)
+	})
+}
+
+func TestASCIIHexRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := []byte("Hello, PostScript!")
+
+	var encoded bytes.Buffer
+	enc := filter.NewASCIIHexEncoder(&encoded)
+	_, err := enc.Write(want)
+	assert.NoError(t, err)
+	assert.NoError(t, enc.Close())
+
+	got, err := io.ReadAll(filter.NewASCIIHexDecoder(&encoded))
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestASCII85RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	want := []byte("Hello, PostScript!")
+
+	var encoded bytes.Buffer
+	enc := filter.NewASCII85Encoder(&encoded)
+	_, err := enc.Write(want)
+	assert.NoError(t, err)
+	assert.NoError(t, enc.Close())
+
+	got, err := io.ReadAll(filter.NewASCII85Decoder(&encoded))
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestASCII85ZeroShortcut(t *testing.T) {
+	t.Parallel()
+
+	want := make([]byte, 4)
+
+	var encoded bytes.Buffer
+	enc := filter.NewASCII85Encoder(&encoded)
+	_, err := enc.Write(want)
+	assert.NoError(t, err)
+	assert.NoError(t, enc.Close())
+
+	assert.Equal(t, "z", encoded.String())
+
+	got, err := io.ReadAll(filter.NewASCII85Decoder(&encoded))
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,104 @@
+package interpret
+
+// Object is a PostScript value: a number, string, name, composite
+// (array/procedure/dict), boolean, or operator.
+type Object interface {
+	isObject()
+}
+
+// IntObject is a PostScript integer.
+type IntObject struct {
+	Value int64
+}
+
+func (IntObject) isObject() {}
+
+// RealObject is a PostScript real number.
+type RealObject struct {
+	Value float64
+}
+
+func (RealObject) isObject() {}
+
+// BoolObject is a PostScript boolean, bound to the names "true" and
+// "false" in systemdict.
+type BoolObject struct {
+	Value bool
+}
+
+func (BoolObject) isObject() {}
+
+// StringObject is a PostScript string: a sequence of bytes, represented
+// here as runes for uniformity with how the scanner reports string
+// contents.
+type StringObject struct {
+	Value []rune
+}
+
+func (StringObject) isObject() {}
+
+// NameObject is an executable name. Encountering one while running a
+// procedure looks the name up in the dictionary stack and invokes the
+// result.
+type NameObject struct {
+	Value string
+}
+
+func (NameObject) isObject() {}
+
+// LiteralNameObject is a "/name": it is always pushed as a value, never
+// looked up or invoked.
+type LiteralNameObject struct {
+	Value string
+}
+
+func (LiteralNameObject) isObject() {}
+
+// ImmediateNameObject is a "//name": it is looked up in the dictionary
+// stack when encountered, but the result is pushed as a value rather
+// than invoked, unlike a plain NameObject.
+type ImmediateNameObject struct {
+	Value string
+}
+
+func (ImmediateNameObject) isObject() {}
+
+// ArrayObject is a literal PostScript array.
+type ArrayObject struct {
+	Elems []Object
+}
+
+func (*ArrayObject) isObject() {}
+
+// ProcedureObject is a literal "{...}" executable array. It is pushed as
+// a value wherever it appears; operators such as if, ifelse, for, and
+// exec are what actually run its Body.
+type ProcedureObject struct {
+	Body []Object
+}
+
+func (*ProcedureObject) isObject() {}
+
+// DictObject is a PostScript dictionary, including a "<<...>>" literal
+// and each frame of the Evaluator's dictionary stack.
+type DictObject struct {
+	Entries map[string]Object
+}
+
+func (*DictObject) isObject() {}
+
+// NewDict returns an empty DictObject.
+func NewDict() *DictObject {
+	return &DictObject{Entries: make(map[string]Object)}
+}
+
+// OperatorFunc implements a built-in PostScript operator.
+type OperatorFunc func(e *Evaluator) error
+
+// OperatorObject wraps a built-in operator so it can live in a
+// dictionary alongside ordinary Objects.
+type OperatorObject struct {
+	Fn OperatorFunc
+}
+
+func (OperatorObject) isObject() {}
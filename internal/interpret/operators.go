@@ -0,0 +1,324 @@
+package interpret
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// registerOperators binds the core operator set and the boolean
+// constants into dict, which is used as systemdict.
+func registerOperators(dict *DictObject) {
+	operators := map[string]OperatorFunc{
+		"add":    opAdd,
+		"sub":    opSub,
+		"mul":    opMul,
+		"div":    opDiv,
+		"def":    opDef,
+		"dup":    opDup,
+		"pop":    opPop,
+		"exch":   opExch,
+		"if":     opIf,
+		"ifelse": opIfElse,
+		"for":    opFor,
+		"exec":   opExec,
+		"=":      opPrint,
+		"stack":  opStack,
+	}
+
+	for name, fn := range operators {
+		dict.Entries[name] = OperatorObject{Fn: fn}
+	}
+
+	dict.Entries["true"] = BoolObject{Value: true}
+	dict.Entries["false"] = BoolObject{Value: false}
+}
+
+func numericValue(obj Object) (float64, bool) {
+	switch v := obj.(type) {
+	case IntObject:
+		return float64(v.Value), true
+	case RealObject:
+		return v.Value, true
+	default:
+		return 0, false
+	}
+}
+
+func (e *Evaluator) popNumber() (Object, error) {
+	obj, err := e.pop()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := numericValue(obj); !ok {
+		return nil, fmt.Errorf("typecheck: expected a number")
+	}
+	return obj, nil
+}
+
+// arithmetic pops two numeric operands and pushes the result of intOp
+// (when both operands are integers) or realOp (otherwise), matching
+// PostScript's rule that mixed int/real arithmetic promotes to real.
+func (e *Evaluator) arithmetic(intOp func(a, b int64) int64, realOp func(a, b float64) float64) error {
+	b, err := e.popNumber()
+	if err != nil {
+		return err
+	}
+	a, err := e.popNumber()
+	if err != nil {
+		return err
+	}
+
+	ai, aIsInt := a.(IntObject)
+	bi, bIsInt := b.(IntObject)
+	if aIsInt && bIsInt {
+		e.push(IntObject{Value: intOp(ai.Value, bi.Value)})
+		return nil
+	}
+
+	af, _ := numericValue(a)
+	bf, _ := numericValue(b)
+	e.push(RealObject{Value: realOp(af, bf)})
+	return nil
+}
+
+func opAdd(e *Evaluator) error {
+	return e.arithmetic(
+		func(a, b int64) int64 { return a + b },
+		func(a, b float64) float64 { return a + b },
+	)
+}
+
+func opSub(e *Evaluator) error {
+	return e.arithmetic(
+		func(a, b int64) int64 { return a - b },
+		func(a, b float64) float64 { return a - b },
+	)
+}
+
+func opMul(e *Evaluator) error {
+	return e.arithmetic(
+		func(a, b int64) int64 { return a * b },
+		func(a, b float64) float64 { return a * b },
+	)
+}
+
+// opDiv implements PostScript's "div", which always yields a real.
+func opDiv(e *Evaluator) error {
+	b, err := e.popNumber()
+	if err != nil {
+		return err
+	}
+	a, err := e.popNumber()
+	if err != nil {
+		return err
+	}
+
+	bf, _ := numericValue(b)
+	if bf == 0 {
+		return fmt.Errorf("undefinedresult: division by zero")
+	}
+	af, _ := numericValue(a)
+
+	e.push(RealObject{Value: af / bf})
+	return nil
+}
+
+func opDef(e *Evaluator) error {
+	value, err := e.pop()
+	if err != nil {
+		return err
+	}
+	key, err := e.pop()
+	if err != nil {
+		return err
+	}
+
+	name, ok := key.(LiteralNameObject)
+	if !ok {
+		return fmt.Errorf("typecheck: def key must be a literal name")
+	}
+
+	e.currentDict().Entries[name.Value] = value
+	return nil
+}
+
+func opDup(e *Evaluator) error {
+	obj, err := e.pop()
+	if err != nil {
+		return err
+	}
+	e.push(obj)
+	e.push(obj)
+	return nil
+}
+
+func opPop(e *Evaluator) error {
+	_, err := e.pop()
+	return err
+}
+
+func opExch(e *Evaluator) error {
+	b, err := e.pop()
+	if err != nil {
+		return err
+	}
+	a, err := e.pop()
+	if err != nil {
+		return err
+	}
+	e.push(b)
+	e.push(a)
+	return nil
+}
+
+func (e *Evaluator) popProcedure() (*ProcedureObject, error) {
+	obj, err := e.pop()
+	if err != nil {
+		return nil, err
+	}
+	proc, ok := obj.(*ProcedureObject)
+	if !ok {
+		return nil, fmt.Errorf("typecheck: expected a procedure")
+	}
+	return proc, nil
+}
+
+func (e *Evaluator) popBool() (bool, error) {
+	obj, err := e.pop()
+	if err != nil {
+		return false, err
+	}
+	b, ok := obj.(BoolObject)
+	if !ok {
+		return false, fmt.Errorf("typecheck: expected a boolean")
+	}
+	return b.Value, nil
+}
+
+func opIf(e *Evaluator) error {
+	proc, err := e.popProcedure()
+	if err != nil {
+		return err
+	}
+	cond, err := e.popBool()
+	if err != nil {
+		return err
+	}
+
+	if cond {
+		return e.run(proc.Body)
+	}
+	return nil
+}
+
+func opIfElse(e *Evaluator) error {
+	elseProc, err := e.popProcedure()
+	if err != nil {
+		return err
+	}
+	thenProc, err := e.popProcedure()
+	if err != nil {
+		return err
+	}
+	cond, err := e.popBool()
+	if err != nil {
+		return err
+	}
+
+	if cond {
+		return e.run(thenProc.Body)
+	}
+	return e.run(elseProc.Body)
+}
+
+func opFor(e *Evaluator) error {
+	proc, err := e.popProcedure()
+	if err != nil {
+		return err
+	}
+	limitObj, err := e.popNumber()
+	if err != nil {
+		return err
+	}
+	incObj, err := e.popNumber()
+	if err != nil {
+		return err
+	}
+	initObj, err := e.popNumber()
+	if err != nil {
+		return err
+	}
+
+	limit, _ := numericValue(limitObj)
+	inc, _ := numericValue(incObj)
+	init, _ := numericValue(initObj)
+	if inc == 0 {
+		return fmt.Errorf("rangecheck: for increment must be nonzero")
+	}
+
+	_, initIsInt := initObj.(IntObject)
+	_, incIsInt := incObj.(IntObject)
+	_, limitIsInt := limitObj.(IntObject)
+	useInt := initIsInt && incIsInt && limitIsInt
+
+	for v := init; (inc > 0 && v <= limit) || (inc < 0 && v >= limit); v += inc {
+		if useInt {
+			e.push(IntObject{Value: int64(v)})
+		} else {
+			e.push(RealObject{Value: v})
+		}
+		if err := e.run(proc.Body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func opExec(e *Evaluator) error {
+	obj, err := e.pop()
+	if err != nil {
+		return err
+	}
+	return e.invoke(obj)
+}
+
+func opPrint(e *Evaluator) error {
+	obj, err := e.pop()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(e.out, formatObject(obj))
+	return nil
+}
+
+func opStack(e *Evaluator) error {
+	for i := len(e.operands) - 1; i >= 0; i-- {
+		fmt.Fprintln(e.out, formatObject(e.operands[i]))
+	}
+	return nil
+}
+
+func formatObject(obj Object) string {
+	switch v := obj.(type) {
+	case IntObject:
+		return strconv.FormatInt(v.Value, 10)
+	case RealObject:
+		return strconv.FormatFloat(v.Value, 'g', -1, 64)
+	case BoolObject:
+		return strconv.FormatBool(v.Value)
+	case StringObject:
+		return string(v.Value)
+	case NameObject:
+		return v.Value
+	case LiteralNameObject:
+		return "/" + v.Value
+	case *ArrayObject:
+		return "--array--"
+	case *ProcedureObject:
+		return "--nostringval--"
+	case *DictObject:
+		return "--dict--"
+	default:
+		return fmt.Sprintf("%v", obj)
+	}
+}
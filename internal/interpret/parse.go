@@ -0,0 +1,199 @@
+package interpret
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ian-shakespeare/libps/internal/interpret/filter"
+)
+
+// Parser turns the Token stream from a Scanner into a tree of PostScript
+// Objects, resolving composite literals ({...}, [...], <<...>>)
+// recursively.
+type Parser struct {
+	s *Scanner
+}
+
+// NewParser creates a Parser that reads tokens from s.
+func NewParser(s *Scanner) *Parser {
+	return &Parser{s: s}
+}
+
+// Parse reads the entire token stream and returns the top-level objects
+// in program order.
+func (p *Parser) Parse() ([]Object, error) {
+	var objects []Object
+
+	for {
+		obj, err := p.parseOne()
+		if err != nil {
+			if err == io.EOF {
+				return objects, nil
+			}
+			return nil, err
+		}
+		objects = append(objects, obj)
+	}
+}
+
+// parseOne reads the next token and converts it into an Object.
+func (p *Parser) parseOne() (Object, error) {
+	token, err := p.s.ReadToken()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseToken(token)
+}
+
+func (p *Parser) parseToken(token Token) (Object, error) {
+	switch token.Type {
+	case INT_TOKEN:
+		v, err := strconv.ParseInt(string(token.Value), 10, 64)
+		if err != nil {
+			return nil, &ScanError{Pos: token.Pos, Msg: "malformed integer"}
+		}
+		return IntObject{Value: v}, nil
+	case REAL_TOKEN:
+		v, err := strconv.ParseFloat(string(token.Value), 64)
+		if err != nil {
+			return nil, &ScanError{Pos: token.Pos, Msg: "malformed real"}
+		}
+		return RealObject{Value: v}, nil
+	case RADIX_TOKEN:
+		v, err := parseRadix(string(token.Value))
+		if err != nil {
+			return nil, &ScanError{Pos: token.Pos, Msg: "malformed radix number"}
+		}
+		return IntObject{Value: v}, nil
+	case LIT_STRING_TOKEN:
+		return StringObject{Value: token.Value}, nil
+	case HEX_STRING_TOKEN:
+		decoded, err := io.ReadAll(filter.NewASCIIHexDecoder(strings.NewReader(string(token.Value))))
+		if err != nil {
+			return nil, &ScanError{Pos: token.Pos, Msg: "malformed hex string"}
+		}
+		return StringObject{Value: bytesToRunes(decoded)}, nil
+	case BASE85_STRING_TOKEN:
+		decoded, err := io.ReadAll(filter.NewASCII85Decoder(strings.NewReader(string(token.Value))))
+		if err != nil {
+			return nil, &ScanError{Pos: token.Pos, Msg: "malformed base85 string"}
+		}
+		return StringObject{Value: bytesToRunes(decoded)}, nil
+	case LIT_NAME_TOKEN:
+		return LiteralNameObject{Value: string(token.Value)}, nil
+	case IMMEDIATE_NAME_TOKEN:
+		return ImmediateNameObject{Value: string(token.Value)}, nil
+	case NAME_TOKEN:
+		return NameObject{Value: string(token.Value)}, nil
+	case PROC_OPEN_TOKEN:
+		return p.parseProcedure(token.Pos)
+	case ARRAY_OPEN_TOKEN:
+		return p.parseArray(token.Pos)
+	case DICT_OPEN_TOKEN:
+		return p.parseDict(token.Pos)
+	default:
+		return nil, &ScanError{Pos: token.Pos, Msg: "unexpected token"}
+	}
+}
+
+func (p *Parser) parseProcedure(start Position) (Object, error) {
+	var body []Object
+
+	for {
+		token, err := p.s.ReadToken()
+		if err != nil {
+			if err == io.EOF {
+				return nil, &ScanError{Pos: start, Msg: "unterminated procedure"}
+			}
+			return nil, err
+		}
+		if token.Type == PROC_CLOSE_TOKEN {
+			return &ProcedureObject{Body: body}, nil
+		}
+
+		obj, err := p.parseToken(token)
+		if err != nil {
+			return nil, err
+		}
+		body = append(body, obj)
+	}
+}
+
+func (p *Parser) parseArray(start Position) (Object, error) {
+	var elems []Object
+
+	for {
+		token, err := p.s.ReadToken()
+		if err != nil {
+			if err == io.EOF {
+				return nil, &ScanError{Pos: start, Msg: "unterminated array"}
+			}
+			return nil, err
+		}
+		if token.Type == ARRAY_CLOSE_TOKEN {
+			return &ArrayObject{Elems: elems}, nil
+		}
+
+		obj, err := p.parseToken(token)
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, obj)
+	}
+}
+
+func (p *Parser) parseDict(start Position) (Object, error) {
+	dict := NewDict()
+
+	for {
+		token, err := p.s.ReadToken()
+		if err != nil {
+			if err == io.EOF {
+				return nil, &ScanError{Pos: start, Msg: "unterminated dict"}
+			}
+			return nil, err
+		}
+		if token.Type == DICT_CLOSE_TOKEN {
+			return dict, nil
+		}
+
+		key, err := p.parseToken(token)
+		if err != nil {
+			return nil, err
+		}
+		name, ok := key.(LiteralNameObject)
+		if !ok {
+			return nil, &ScanError{Pos: token.Pos, Msg: "dict key must be a literal name"}
+		}
+
+		value, err := p.parseOne()
+		if err != nil {
+			return nil, err
+		}
+
+		dict.Entries[name.Value] = value
+	}
+}
+
+// bytesToRunes converts decoded filter output into the []rune
+// representation StringObject uses, one rune per raw byte, since
+// decoded string content is not necessarily valid UTF-8.
+func bytesToRunes(b []byte) []rune {
+	value := make([]rune, len(b))
+	for i, c := range b {
+		value[i] = rune(c)
+	}
+	return value
+}
+
+func parseRadix(s string) (int64, error) {
+	base, digits, _ := strings.Cut(s, "#")
+
+	b, err := strconv.ParseInt(base, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(digits, int(b), 64)
+}
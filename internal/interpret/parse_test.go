@@ -0,0 +1,159 @@
+package interpret_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ian-shakespeare/libps/internal/interpret"
+	"github.com/stretchr/testify/assert"
+)
+
+func parseAll(t *testing.T, input string) []interpret.Object {
+	t.Helper()
+
+	objects, err := interpret.NewParser(interpret.NewScanner(strings.NewReader(input))).Parse()
+	assert.NoError(t, err)
+	return objects
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("literals", func(t *testing.T) {
+		t.Parallel()
+
+		objects := parseAll(t, "1 2.5 (hi) /foo bar")
+
+		assert.Equal(t, []interpret.Object{
+			interpret.IntObject{Value: 1},
+			interpret.RealObject{Value: 2.5},
+			interpret.StringObject{Value: []rune("hi")},
+			interpret.LiteralNameObject{Value: "foo"},
+			interpret.NameObject{Value: "bar"},
+		}, objects)
+	})
+
+	t.Run("immediateName", func(t *testing.T) {
+		t.Parallel()
+
+		objects := parseAll(t, "//foo")
+
+		assert.Equal(t, []interpret.Object{
+			interpret.ImmediateNameObject{Value: "foo"},
+		}, objects)
+	})
+
+	t.Run("radix", func(t *testing.T) {
+		t.Parallel()
+
+		objects := parseAll(t, "16#FF 2#101")
+
+		assert.Equal(t, []interpret.Object{
+			interpret.IntObject{Value: 255},
+			interpret.IntObject{Value: 5},
+		}, objects)
+	})
+
+	t.Run("procedure", func(t *testing.T) {
+		t.Parallel()
+
+		objects := parseAll(t, "{ 1 add }")
+		assert.Len(t, objects, 1)
+
+		proc, ok := objects[0].(*interpret.ProcedureObject)
+		assert.True(t, ok)
+		assert.Equal(t, []interpret.Object{
+			interpret.IntObject{Value: 1},
+			interpret.NameObject{Value: "add"},
+		}, proc.Body)
+	})
+
+	t.Run("array", func(t *testing.T) {
+		t.Parallel()
+
+		objects := parseAll(t, "[ 1 2 3 ]")
+		assert.Len(t, objects, 1)
+
+		arr, ok := objects[0].(*interpret.ArrayObject)
+		assert.True(t, ok)
+		assert.Equal(t, []interpret.Object{
+			interpret.IntObject{Value: 1},
+			interpret.IntObject{Value: 2},
+			interpret.IntObject{Value: 3},
+		}, arr.Elems)
+	})
+
+	t.Run("dict", func(t *testing.T) {
+		t.Parallel()
+
+		objects := parseAll(t, "<< /a 1 /b (two) >>")
+		assert.Len(t, objects, 1)
+
+		dict, ok := objects[0].(*interpret.DictObject)
+		assert.True(t, ok)
+		assert.Equal(t, map[string]interpret.Object{
+			"a": interpret.IntObject{Value: 1},
+			"b": interpret.StringObject{Value: []rune("two")},
+		}, dict.Entries)
+	})
+
+	t.Run("hexString", func(t *testing.T) {
+		t.Parallel()
+
+		objects := parseAll(t, "<901fa>")
+
+		assert.Equal(t, []interpret.Object{
+			interpret.StringObject{Value: []rune{0x90, 0x1f, 0xa0}},
+		}, objects)
+	})
+
+	t.Run("base85String", func(t *testing.T) {
+		t.Parallel()
+
+		objects := parseAll(t, "<~87cURD_*#4DfTZ)+T~>")
+
+		assert.Equal(t, []interpret.Object{
+			interpret.StringObject{Value: []rune("Hello, World!")},
+		}, objects)
+	})
+
+	t.Run("unterminatedProcedure", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := interpret.NewParser(interpret.NewScanner(strings.NewReader("{ 1 2"))).Parse()
+		assert.Error(t, err)
+	})
+
+	t.Run("unterminatedProcedureReportsOpeningPosition", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := interpret.NewParser(interpret.NewScanner(
+			strings.NewReader("\n\n{ 1 2"),
+			interpret.WithFilename("test.ps"),
+		)).Parse()
+
+		var scanErr *interpret.ScanError
+		assert.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, interpret.Position{Filename: "test.ps", Offset: 2, Line: 3, Column: 1}, scanErr.Pos)
+	})
+
+	t.Run("unterminatedArrayReportsOpeningPosition", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := interpret.NewParser(interpret.NewScanner(strings.NewReader("[ 1 2"))).Parse()
+
+		var scanErr *interpret.ScanError
+		assert.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, interpret.Position{Line: 1, Column: 1}, scanErr.Pos)
+	})
+
+	t.Run("unterminatedDictReportsOpeningPosition", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := interpret.NewParser(interpret.NewScanner(strings.NewReader("<< /a 1"))).Parse()
+
+		var scanErr *interpret.ScanError
+		assert.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, interpret.Position{Line: 1, Column: 1}, scanErr.Pos)
+	})
+}
@@ -0,0 +1,502 @@
+// Package interpret implements a scanner (and, eventually, parser and
+// evaluator) for the PostScript language.
+package interpret
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"unicode/utf8"
+)
+
+// TokenType identifies the lexical class of a Token.
+type TokenType int
+
+const (
+	NAME_TOKEN TokenType = iota
+	INT_TOKEN
+	REAL_TOKEN
+	RADIX_TOKEN
+	LIT_STRING_TOKEN
+	HEX_STRING_TOKEN
+	BASE85_STRING_TOKEN
+	LIT_NAME_TOKEN
+	IMMEDIATE_NAME_TOKEN
+	PROC_OPEN_TOKEN
+	PROC_CLOSE_TOKEN
+	DICT_OPEN_TOKEN
+	DICT_CLOSE_TOKEN
+	ARRAY_OPEN_TOKEN
+	ARRAY_CLOSE_TOKEN
+)
+
+// Position identifies a location in the scanned source, mirroring
+// text/scanner.Position.
+type Position struct {
+	Filename string
+	Offset   int // byte offset, starting at 0
+	Line     int // line number, starting at 1
+	Column   int // column number (in runes), starting at 1
+}
+
+func (p Position) String() string {
+	filename := p.Filename
+	if filename == "" {
+		filename = "<input>"
+	}
+	return fmt.Sprintf("%s:%d:%d", filename, p.Line, p.Column)
+}
+
+// ScanError reports a lexical error together with the position at which it
+// occurred.
+type ScanError struct {
+	Pos Position
+	Msg string
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// Token is a single lexical unit produced by a Scanner.
+type Token struct {
+	Type  TokenType
+	Value []rune
+	Pos   Position
+}
+
+// Option configures a Scanner. See NewScanner.
+type Option func(*Scanner)
+
+// WithFilename sets the name reported in the Position of every Token and
+// ScanError produced by the Scanner.
+func WithFilename(filename string) Option {
+	return func(s *Scanner) {
+		s.pos.Filename = filename
+	}
+}
+
+// snapshot captures everything needed to rewind the Scanner's position
+// tracking when a rune is unread.
+type snapshot struct {
+	pos     Position
+	afterCR bool
+}
+
+// Scanner reads PostScript source and produces a stream of Tokens.
+type Scanner struct {
+	r        *bufio.Reader
+	pos      Position
+	afterCR  bool
+	pushback []rune
+	history  []snapshot
+}
+
+// NewScanner creates a Scanner that reads from r.
+func NewScanner(r io.Reader, opts ...Option) *Scanner {
+	s := &Scanner{
+		r:   bufio.NewReader(r),
+		pos: Position{Line: 1, Column: 1},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Pos reports the position of the next rune the Scanner will read.
+func (s *Scanner) Pos() Position {
+	return s.pos
+}
+
+// next returns the next rune, advancing the Scanner's position. \r and
+// \r\n are counted as a single line break, but the runes themselves are
+// returned unmodified so that callers (e.g. string literals) see the
+// source exactly as written.
+func (s *Scanner) next() (rune, error) {
+	var r rune
+
+	if n := len(s.pushback); n > 0 {
+		r = s.pushback[n-1]
+		s.pushback = s.pushback[:n-1]
+	} else {
+		raw, _, err := s.r.ReadRune()
+		if err != nil {
+			return 0, err
+		}
+		r = raw
+	}
+
+	s.history = append(s.history, snapshot{pos: s.pos, afterCR: s.afterCR})
+	s.pos = s.advance(s.pos, r)
+
+	return r, nil
+}
+
+// unread pushes r back onto the Scanner so the next call to next returns it
+// again, restoring the position as it was before r was read.
+func (s *Scanner) unread(r rune) {
+	n := len(s.history)
+	snap := s.history[n-1]
+	s.history = s.history[:n-1]
+
+	s.pos, s.afterCR = snap.pos, snap.afterCR
+	s.pushback = append(s.pushback, r)
+}
+
+func (s *Scanner) advance(p Position, r rune) Position {
+	np := p
+	np.Offset += utf8.RuneLen(r)
+
+	if r == '\n' && s.afterCR {
+		// Second half of a \r\n pair: already counted as a line break.
+		s.afterCR = false
+		return np
+	}
+
+	s.afterCR = r == '\r'
+
+	if r == '\n' || r == '\r' {
+		np.Line++
+		np.Column = 1
+	} else {
+		np.Column++
+	}
+
+	return np
+}
+
+func isWhitespace(r rune) bool {
+	switch r {
+	case ' ', '\t', '\n', '\r', '\f', 0:
+		return true
+	default:
+		return false
+	}
+}
+
+func isDelimiter(r rune) bool {
+	switch r {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	default:
+		return false
+	}
+}
+
+// ReadToken reads and returns the next Token. It returns io.EOF once the
+// underlying reader is exhausted.
+func (s *Scanner) ReadToken() (Token, error) {
+	if err := s.skipWhitespaceAndComments(); err != nil {
+		return Token{}, err
+	}
+
+	start := s.pos
+
+	r, err := s.next()
+	if err != nil {
+		return Token{}, err
+	}
+
+	switch r {
+	case '(':
+		return s.scanLiteralString(start)
+	case '<':
+		return s.scanAngleOpen(start)
+	case '>':
+		return s.scanAngleClose(start)
+	case '{':
+		return Token{Type: PROC_OPEN_TOKEN, Value: []rune{r}, Pos: start}, nil
+	case '}':
+		return Token{Type: PROC_CLOSE_TOKEN, Value: []rune{r}, Pos: start}, nil
+	case '[':
+		return Token{Type: ARRAY_OPEN_TOKEN, Value: []rune{r}, Pos: start}, nil
+	case ']':
+		return Token{Type: ARRAY_CLOSE_TOKEN, Value: []rune{r}, Pos: start}, nil
+	case '/':
+		return s.scanNameLiteral(start)
+	default:
+		return s.scanRegular(start, r)
+	}
+}
+
+func (s *Scanner) skipWhitespaceAndComments() error {
+	for {
+		r, err := s.next()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case isWhitespace(r):
+			continue
+		case r == '%':
+			if err := s.skipComment(); err != nil {
+				return err
+			}
+		default:
+			s.unread(r)
+			return nil
+		}
+	}
+}
+
+func (s *Scanner) skipComment() error {
+	for {
+		r, err := s.next()
+		if err != nil {
+			return err
+		}
+		if r == '\n' {
+			return nil
+		}
+	}
+}
+
+// scanRegular reads a run of non-delimiter, non-whitespace runes and
+// classifies the result as an integer, real, radix number, or name.
+func (s *Scanner) scanRegular(start Position, first rune) (Token, error) {
+	value := []rune{first}
+
+	for {
+		r, err := s.next()
+		if err != nil {
+			if err != io.EOF {
+				return Token{}, err
+			}
+			break
+		}
+		if isWhitespace(r) || isDelimiter(r) {
+			s.unread(r)
+			break
+		}
+		value = append(value, r)
+	}
+
+	return Token{Type: classifyRegular(value), Value: value, Pos: start}, nil
+}
+
+var (
+	intPattern   = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	realPattern  = regexp.MustCompile(`^[+-]?([0-9]+\.[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?$`)
+	radixPattern = regexp.MustCompile(`^[0-9]+#[0-9A-Za-z]+$`)
+)
+
+func classifyRegular(value []rune) TokenType {
+	s := string(value)
+
+	switch {
+	case intPattern.MatchString(s):
+		return INT_TOKEN
+	case radixPattern.MatchString(s):
+		return RADIX_TOKEN
+	case realPattern.MatchString(s):
+		return REAL_TOKEN
+	default:
+		return NAME_TOKEN
+	}
+}
+
+// scanLiteralString reads a "(...)" string, the opening paren having
+// already been consumed.
+func (s *Scanner) scanLiteralString(start Position) (Token, error) {
+	value := []rune{}
+	depth := 1
+
+	for {
+		r, err := s.next()
+		if err != nil {
+			return Token{}, &ScanError{Pos: start, Msg: "unterminated string"}
+		}
+
+		switch r {
+		case '(':
+			depth++
+			value = append(value, r)
+		case ')':
+			depth--
+			if depth == 0 {
+				return Token{Type: LIT_STRING_TOKEN, Value: value, Pos: start}, nil
+			}
+			value = append(value, r)
+		case '\\':
+			esc, ok, err := s.scanEscape()
+			if err != nil {
+				return Token{}, &ScanError{Pos: start, Msg: "unterminated string"}
+			}
+			if ok {
+				value = append(value, esc)
+			}
+		default:
+			value = append(value, r)
+		}
+	}
+}
+
+// scanEscape reads the character(s) following a backslash inside a literal
+// string. ok is false when the escape contributes no rune to the string
+// (a line-continuation escape).
+func (s *Scanner) scanEscape() (rune, bool, error) {
+	r, err := s.next()
+	if err != nil {
+		return 0, false, err
+	}
+
+	switch r {
+	case 'n':
+		return '\n', true, nil
+	case 'r':
+		return '\r', true, nil
+	case 't':
+		return '\t', true, nil
+	case 'b':
+		return '\b', true, nil
+	case 'f':
+		return '\f', true, nil
+	case '\\', '(', ')':
+		return r, true, nil
+	case '\n':
+		return 0, false, nil
+	case '\r':
+		if pk, err := s.next(); err == nil && pk != '\n' {
+			s.unread(pk)
+		}
+		return 0, false, nil
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		value := r - '0'
+		for i := 0; i < 2; i++ {
+			d, err := s.next()
+			if err != nil {
+				break
+			}
+			if d < '0' || d > '7' {
+				s.unread(d)
+				break
+			}
+			value = value*8 + (d - '0')
+		}
+		return value, true, nil
+	default:
+		return r, true, nil
+	}
+}
+
+// scanAngleOpen disambiguates the three constructs that start with '<':
+// a "<<" dictionary literal, a "<~...~>" base-85 string, and a "<...>"
+// hex string. The opening '<' has already been consumed.
+func (s *Scanner) scanAngleOpen(start Position) (Token, error) {
+	r, err := s.next()
+	if err != nil {
+		return Token{}, &ScanError{Pos: start, Msg: "unterminated string"}
+	}
+
+	switch r {
+	case '<':
+		return Token{Type: DICT_OPEN_TOKEN, Value: []rune("<<"), Pos: start}, nil
+	case '~':
+		return s.scanBase85String(start)
+	default:
+		s.unread(r)
+		return s.scanHexString(start)
+	}
+}
+
+// scanAngleClose reads a ">>" dictionary-close token. The opening '>' has
+// already been consumed; '>' never appears on its own outside of a hex or
+// base-85 string, both of which consume their own closing '>'.
+func (s *Scanner) scanAngleClose(start Position) (Token, error) {
+	r, err := s.next()
+	if err != nil || r != '>' {
+		if err == nil {
+			s.unread(r)
+		}
+		return Token{}, &ScanError{Pos: start, Msg: "unexpected '>'"}
+	}
+
+	return Token{Type: DICT_CLOSE_TOKEN, Value: []rune(">>"), Pos: start}, nil
+}
+
+// scanNameLiteral reads a "/name" literal-name token or a "//name"
+// immediately-evaluated-name token. The leading '/' has already been
+// consumed.
+func (s *Scanner) scanNameLiteral(start Position) (Token, error) {
+	tokenType := LIT_NAME_TOKEN
+
+	if r, err := s.next(); err == nil {
+		if r == '/' {
+			tokenType = IMMEDIATE_NAME_TOKEN
+		} else {
+			s.unread(r)
+		}
+	}
+
+	var value []rune
+	for {
+		r, err := s.next()
+		if err != nil {
+			if err != io.EOF {
+				return Token{}, err
+			}
+			break
+		}
+		if isWhitespace(r) || isDelimiter(r) {
+			s.unread(r)
+			break
+		}
+		value = append(value, r)
+	}
+
+	return Token{Type: tokenType, Value: value, Pos: start}, nil
+}
+
+func (s *Scanner) scanHexString(start Position) (Token, error) {
+	var digits []rune
+
+	for {
+		r, err := s.next()
+		if err != nil {
+			return Token{}, &ScanError{Pos: start, Msg: "unterminated hex string"}
+		}
+		if r == '>' {
+			break
+		}
+		if isWhitespace(r) {
+			continue
+		}
+		digits = append(digits, r)
+	}
+
+	if len(digits)%2 != 0 {
+		digits = append(digits, '0')
+	}
+
+	return Token{Type: HEX_STRING_TOKEN, Value: digits, Pos: start}, nil
+}
+
+func (s *Scanner) scanBase85String(start Position) (Token, error) {
+	var value []rune
+
+	for {
+		r, err := s.next()
+		if err != nil {
+			return Token{}, &ScanError{Pos: start, Msg: "unterminated base85 string"}
+		}
+		if r == '~' {
+			closing, err := s.next()
+			if err != nil {
+				return Token{}, &ScanError{Pos: start, Msg: "unterminated base85 string"}
+			}
+			if closing == '>' {
+				break
+			}
+			value = append(value, r, closing)
+			continue
+		}
+		value = append(value, r)
+	}
+
+	return Token{Type: BASE85_STRING_TOKEN, Value: value, Pos: start}, nil
+}
@@ -0,0 +1,99 @@
+package interpret_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/ian-shakespeare/libps/internal/interpret"
+	"github.com/stretchr/testify/assert"
+)
+
+// segmentedReader returns the underlying data a few bytes at a time,
+// regardless of how large a buffer the caller offers, simulating a
+// network socket or pipe that never delivers a full token in one Read.
+type segmentedReader struct {
+	data  []byte
+	pos   int
+	sizes []int
+	calls int
+}
+
+func newSegmentedReader(s string) *segmentedReader {
+	return &segmentedReader{data: []byte(s), sizes: []int{1, 2, 3}}
+}
+
+func (r *segmentedReader) Read(p []byte) (int, error) {
+	if r.pos >= len(r.data) {
+		return 0, io.EOF
+	}
+
+	size := r.sizes[r.calls%len(r.sizes)]
+	r.calls++
+
+	if size > len(p) {
+		size = len(p)
+	}
+	if r.pos+size > len(r.data) {
+		size = len(r.data) - r.pos
+	}
+
+	n := copy(p, r.data[r.pos:r.pos+size])
+	r.pos += n
+
+	return n, nil
+}
+
+func readAllTokens(t *testing.T, s *interpret.Scanner) []interpret.Token {
+	t.Helper()
+
+	var tokens []interpret.Token
+	for {
+		token, err := s.ReadToken()
+		if errors.Is(err, io.EOF) {
+			return tokens
+		}
+		assert.NoError(t, err)
+		tokens = append(tokens, token)
+	}
+}
+
+func TestScanSegmented(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		name  string
+		value string
+	}{
+		{
+			"numbersAndNames",
+			"myStr (i have a string right here) myInt 1234567890 myNegativeReal -1.2e-7 2#1000",
+		},
+		{
+			"multibyteUtf8",
+			"(héllo wörld) café 日本語",
+		},
+		{
+			"dictArrayProc",
+			"<< /Key <DEADBEEF> /Other [ 1 2 3 ] >> { 3 4 add } //immediate",
+		},
+		{
+			"escapedStringAndBase85",
+			"(escaped \\n \\t \\( \\) string) <~FD,B0+DGm>F)Po,+EV1>F8~>",
+		},
+	}
+
+	for _, input := range inputs {
+		input := input
+
+		t.Run(input.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := readAllTokens(t, interpret.NewScanner(strings.NewReader(input.value)))
+			got := readAllTokens(t, interpret.NewScanner(newSegmentedReader(input.value)))
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
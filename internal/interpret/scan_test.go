@@ -28,6 +28,8 @@ func TestScan(t *testing.T) {
 	}
 
 	for _, input := range invalidNumerics {
+		input := input
+
 		t.Run(input.name, func(t *testing.T) {
 			t.Parallel()
 
@@ -63,16 +65,54 @@ func TestScan(t *testing.T) {
 	}
 
 	for _, input := range validNumerics {
+		input := input
+
 		t.Run(input.name, func(t *testing.T) {
 			t.Parallel()
 
 			s := interpret.NewScanner(strings.NewReader(input.value))
 			token, err := s.ReadToken()
 			assert.NoError(t, err)
-			assert.Equal(t, interpret.Token{Type: input.tokenType, Value: []rune(input.value)}, token)
+			assert.Equal(t, interpret.Token{
+				Type:  input.tokenType,
+				Value: []rune(input.value),
+				Pos:   interpret.Position{Line: 1, Column: 1},
+			}, token)
 		})
 	}
 
+	t.Run("position", func(t *testing.T) {
+		t.Parallel()
+
+		s := interpret.NewScanner(strings.NewReader("foo\nbar baz"), interpret.WithFilename("test.ps"))
+
+		foo, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.Position{Filename: "test.ps", Line: 1, Column: 1}, foo.Pos)
+
+		bar, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.Position{Filename: "test.ps", Offset: 4, Line: 2, Column: 1}, bar.Pos)
+
+		baz, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.Position{Filename: "test.ps", Offset: 8, Line: 2, Column: 5}, baz.Pos)
+	})
+
+	t.Run("scanErrorHasPosition", func(t *testing.T) {
+		t.Parallel()
+
+		s := interpret.NewScanner(strings.NewReader("ok (unterminated"), interpret.WithFilename("test.ps"))
+
+		_, err := s.ReadToken()
+		assert.NoError(t, err)
+
+		_, err = s.ReadToken()
+		var scanErr *interpret.ScanError
+		assert.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, interpret.Position{Filename: "test.ps", Offset: 3, Line: 1, Column: 4}, scanErr.Pos)
+	})
+
 	t.Run("stringUnterminated", func(t *testing.T) {
 		t.Parallel()
 
@@ -95,6 +135,8 @@ func TestScan(t *testing.T) {
 	}
 
 	for _, input := range validStrings {
+		input := input
+
 		t.Run(input.name, func(t *testing.T) {
 			t.Parallel()
 
@@ -125,6 +167,8 @@ func TestScan(t *testing.T) {
 	}
 
 	for _, input := range escapedStrings {
+		input := input
+
 		t.Run(input.name, func(t *testing.T) {
 			t.Parallel()
 
@@ -154,6 +198,8 @@ func TestScan(t *testing.T) {
 	}
 
 	for _, input := range octals {
+		input := input
+
 		t.Run(input.name, func(t *testing.T) {
 			t.Parallel()
 
@@ -178,6 +224,8 @@ func TestScan(t *testing.T) {
 	}
 
 	for _, input := range hexStrings {
+		input := input
+
 		t.Run(input.name, func(t *testing.T) {
 			t.Parallel()
 
@@ -227,6 +275,82 @@ func TestScan(t *testing.T) {
 		}
 	})
 
+	t.Run("literalName", func(t *testing.T) {
+		t.Parallel()
+
+		s := interpret.NewScanner(strings.NewReader("/foo"))
+		token, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.LIT_NAME_TOKEN, token.Type)
+		assert.Equal(t, "foo", string(token.Value))
+	})
+
+	t.Run("immediateName", func(t *testing.T) {
+		t.Parallel()
+
+		s := interpret.NewScanner(strings.NewReader("//foo"))
+		token, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.IMMEDIATE_NAME_TOKEN, token.Type)
+		assert.Equal(t, "foo", string(token.Value))
+	})
+
+	punctuation := []struct {
+		name      string
+		value     string
+		tokenType interpret.TokenType
+	}{
+		{"procOpen", "{", interpret.PROC_OPEN_TOKEN},
+		{"procClose", "}", interpret.PROC_CLOSE_TOKEN},
+		{"arrayOpen", "[", interpret.ARRAY_OPEN_TOKEN},
+		{"arrayClose", "]", interpret.ARRAY_CLOSE_TOKEN},
+		{"dictOpen", "<<", interpret.DICT_OPEN_TOKEN},
+		{"dictClose", ">>", interpret.DICT_CLOSE_TOKEN},
+	}
+
+	for _, input := range punctuation {
+		input := input
+
+		t.Run(input.name, func(t *testing.T) {
+			t.Parallel()
+
+			s := interpret.NewScanner(strings.NewReader(input.value))
+			token, err := s.ReadToken()
+			assert.NoError(t, err)
+			assert.Equal(t, input.tokenType, token.Type)
+			assert.Equal(t, input.value, string(token.Value))
+		})
+	}
+
+	t.Run("angleBracketDisambiguation", func(t *testing.T) {
+		t.Parallel()
+
+		s := interpret.NewScanner(strings.NewReader("<< /a <DEAD> >> <~FD,B0~>"))
+
+		dictOpen, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.DICT_OPEN_TOKEN, dictOpen.Type)
+
+		name, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.LIT_NAME_TOKEN, name.Type)
+		assert.Equal(t, "a", string(name.Value))
+
+		hexString, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.HEX_STRING_TOKEN, hexString.Type)
+		assert.Equal(t, "DEAD", string(hexString.Value))
+
+		dictClose, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.DICT_CLOSE_TOKEN, dictClose.Type)
+
+		base85String, err := s.ReadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, interpret.BASE85_STRING_TOKEN, base85String.Type)
+		assert.Equal(t, "FD,B0", string(base85String.Value))
+	})
+
 	t.Run("all", func(t *testing.T) {
 		t.Parallel()
 
@@ -244,18 +368,18 @@ myNegativeReal -3.1456
     `
 
 		expect := []interpret.Token{
-			{Type: interpret.NAME_TOKEN, Value: []rune("myStr")},
-			{Type: interpret.LIT_STRING_TOKEN, Value: []rune("i have a string right here")},
-			{Type: interpret.NAME_TOKEN, Value: []rune("myOtherStr")},
-			{Type: interpret.LIT_STRING_TOKEN, Value: []rune("and\nanother right here")},
-			{Type: interpret.NAME_TOKEN, Value: []rune("myInt")},
-			{Type: interpret.INT_TOKEN, Value: []rune("1234567890")},
-			{Type: interpret.NAME_TOKEN, Value: []rune("myNegativeInt")},
-			{Type: interpret.INT_TOKEN, Value: []rune("-1234567890")},
-			{Type: interpret.NAME_TOKEN, Value: []rune("myReal")},
-			{Type: interpret.REAL_TOKEN, Value: []rune("3.1456")},
-			{Type: interpret.NAME_TOKEN, Value: []rune("myNegativeReal")},
-			{Type: interpret.REAL_TOKEN, Value: []rune("-3.1456")},
+			{Type: interpret.NAME_TOKEN, Value: []rune("myStr"), Pos: interpret.Position{Offset: 1, Line: 2, Column: 1}},
+			{Type: interpret.LIT_STRING_TOKEN, Value: []rune("i have a string right here"), Pos: interpret.Position{Offset: 7, Line: 2, Column: 7}},
+			{Type: interpret.NAME_TOKEN, Value: []rune("myOtherStr"), Pos: interpret.Position{Offset: 36, Line: 3, Column: 1}},
+			{Type: interpret.LIT_STRING_TOKEN, Value: []rune("and\nanother right here"), Pos: interpret.Position{Offset: 47, Line: 3, Column: 12}},
+			{Type: interpret.NAME_TOKEN, Value: []rune("myInt"), Pos: interpret.Position{Offset: 96, Line: 8, Column: 1}},
+			{Type: interpret.INT_TOKEN, Value: []rune("1234567890"), Pos: interpret.Position{Offset: 102, Line: 8, Column: 7}},
+			{Type: interpret.NAME_TOKEN, Value: []rune("myNegativeInt"), Pos: interpret.Position{Offset: 113, Line: 9, Column: 1}},
+			{Type: interpret.INT_TOKEN, Value: []rune("-1234567890"), Pos: interpret.Position{Offset: 127, Line: 9, Column: 15}},
+			{Type: interpret.NAME_TOKEN, Value: []rune("myReal"), Pos: interpret.Position{Offset: 139, Line: 10, Column: 1}},
+			{Type: interpret.REAL_TOKEN, Value: []rune("3.1456"), Pos: interpret.Position{Offset: 146, Line: 10, Column: 8}},
+			{Type: interpret.NAME_TOKEN, Value: []rune("myNegativeReal"), Pos: interpret.Position{Offset: 153, Line: 11, Column: 1}},
+			{Type: interpret.REAL_TOKEN, Value: []rune("-3.1456"), Pos: interpret.Position{Offset: 168, Line: 11, Column: 16}},
 		}
 
 		s := interpret.NewScanner(strings.NewReader(input))